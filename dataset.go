@@ -0,0 +1,32 @@
+package dicom
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/suyashkumar/dicom/pkg/tag"
+)
+
+// ErrorElementNotFound indicates that FindElementByTag could not find an element with the requested tag
+// in the Dataset.
+var ErrorElementNotFound = errors.New("could not find element in dataset")
+
+// Dataset represents a DICOM dataset, a flat collection of Elements (see the DICOM standard:
+// http://dicom.nema.org/medical/dicom/current/output/html/part05.html#chapter_7 ). Nested elements (e.g.
+// the items of a sequence) are represented on the owning Element's Value, not here.
+type Dataset struct {
+	Elements []*Element
+}
+
+// FindElementByTag looks for an Element with the given tag directly within ds.Elements, returning
+// ErrorElementNotFound if none is present. It does not recurse into sequences, and neither does the
+// generic FindElement helper built on it; to search nested elements, walk ds.Elements and the items
+// returned by the AsSequence accessor directly.
+func FindElementByTag(ds *Dataset, t tag.Tag) (*Element, error) {
+	for _, elem := range ds.Elements {
+		if elem.Tag == t {
+			return elem, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: tag %s", ErrorElementNotFound, t.String())
+}
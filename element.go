@@ -40,14 +40,14 @@ func (e *Element) String() string {
 
 // Value represents a DICOM value. The underlying data that a Value stores can be determined by inspecting its
 // ValueType. DICOM values typically can be one of many types (ints, strings, bytes, sequences of other elements, etc),
-// so this Value interface attempts to represent this as canoically as possible in Golang (since generics do not exist
-// yet).
+// so this Value interface attempts to represent this as canoically as possible in Golang.
 //
 // Value is JSON serializable out of the box (implements json.Marshaler).
 //
 // If necessary, a Value's data can be efficiently unpacked by inspecting its underlying ValueType and either using a
-// Golang type assertion or using the helper functions provided (like MustGetStrings). Because for each ValueType there
-// is exactly one underlying Golang type, this should be safe, efficient, and straightforward.
+// Golang type assertion, the generic GetValue/MustGetValue helpers, or the older helper functions provided (like
+// MustGetStrings). Because for each ValueType there is exactly one underlying Golang type, this should be safe,
+// efficient, and straightforward.
 //
 //	switch(myvalue.ValueType()) {
 //		case dicom.Strings:
@@ -78,7 +78,9 @@ type Value interface {
 }
 
 // NewValue creates a new DICOM value for the supplied data. Likely most useful if creating an Element in testing or
-// write scenarios.
+// write scenarios. NewValue has no knowledge of the VR the value will be stored under; see NewValueForVR for a
+// VR-aware constructor that validates data against the target VR (e.g. integer ranges) and accepts richer input
+// types (PersonName, time.Time).
 //
 // Data must be one of the following types, otherwise and error will be returned (ErrorUnexpectedDataType).
 //
@@ -113,7 +115,7 @@ func newElement(t tag.Tag, data interface{}) (*Element, error) {
 	}
 	rawVR := tagInfo.VR
 
-	value, err := NewValue(data)
+	value, err := NewValueForVR(rawVR, data)
 	if err != nil {
 		return nil, err
 	}
@@ -254,30 +256,18 @@ func (s *pixelDataValue) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.PixelDataInfo)
 }
 
-func MustGetInts(v Value) []int {
-	if v.ValueType() != Ints {
-		log.Panicf("MustGetInts expected ValueType of Ints, got: %v", v.ValueType())
-	}
-	return v.GetValue().([]int)
-}
+// MustGetInts is kept for backward compatibility; prefer the generic GetValue/MustGetValue (or the
+// AsInts wrapper) in new code.
+func MustGetInts(v Value) []int { return MustGetValue[[]int](v) }
 
-func MustGetStrings(v Value) []string {
-	if v.ValueType() != Strings {
-		log.Panicf("MustGetStrings expected ValueType of Strings, got: %v", v.ValueType())
-	}
-	return v.GetValue().([]string)
-}
+// MustGetStrings is kept for backward compatibility; prefer the generic GetValue/MustGetValue (or the
+// AsStrings wrapper) in new code.
+func MustGetStrings(v Value) []string { return MustGetValue[[]string](v) }
 
-func MustGetBytes(v Value) []byte {
-	if v.ValueType() != Bytes {
-		log.Panicf("MustGetBytes expected ValueType of Bytes, got: %v", v.ValueType())
-	}
-	return v.GetValue().([]byte)
-}
+// MustGetBytes is kept for backward compatibility; prefer the generic GetValue/MustGetValue (or the
+// AsBytes wrapper) in new code.
+func MustGetBytes(v Value) []byte { return MustGetValue[[]byte](v) }
 
-func MustGetPixelDataInfo(v Value) PixelDataInfo {
-	if v.ValueType() != PixelData {
-		log.Panicf("MustGetPixelDataInfo expected ValueType of PixelData, got: %v", v.ValueType())
-	}
-	return v.GetValue().(PixelDataInfo)
-}
+// MustGetPixelDataInfo is kept for backward compatibility; prefer the generic GetValue/MustGetValue (or
+// the AsPixelData wrapper) in new code.
+func MustGetPixelDataInfo(v Value) PixelDataInfo { return MustGetValue[PixelDataInfo](v) }
@@ -0,0 +1,98 @@
+package dicom
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/suyashkumar/dicom/pkg/tag"
+)
+
+// GetValue extracts the underlying data held by v as the requested type T, which must be one of the
+// concrete types a Value can hold: []string, []int, []byte, PixelDataInfo, or [][]*Element (for
+// sequences). It returns an error if v does not hold a value of that type.
+//
+// GetValue is the generic counterpart to the MustGet* family, and lets callers write:
+//
+//	strs, err := dicom.GetValue[[]string](elem.Value)
+func GetValue[T any](v Value) (T, error) {
+	var zero T
+	switch any(zero).(type) {
+	case []string:
+		if v.ValueType() != Strings {
+			return zero, fmt.Errorf("dicom: expected Strings value, got %v", v.ValueType())
+		}
+	case []int:
+		if v.ValueType() != Ints {
+			return zero, fmt.Errorf("dicom: expected Ints value, got %v", v.ValueType())
+		}
+	case []byte:
+		if v.ValueType() != Bytes {
+			return zero, fmt.Errorf("dicom: expected Bytes value, got %v", v.ValueType())
+		}
+	case PixelDataInfo:
+		if v.ValueType() != PixelData {
+			return zero, fmt.Errorf("dicom: expected PixelData value, got %v", v.ValueType())
+		}
+	case [][]*Element:
+		if v.ValueType() != Sequences {
+			return zero, fmt.Errorf("dicom: expected Sequences value, got %v", v.ValueType())
+		}
+		items := v.GetValue().([]*SequenceItemValue)
+		seq := make([][]*Element, len(items))
+		for i, item := range items {
+			seq[i] = item.GetValue().([]*Element)
+		}
+		typed, ok := any(seq).(T)
+		if !ok {
+			return zero, fmt.Errorf("dicom: cannot represent Sequences value as %T", zero)
+		}
+		return typed, nil
+	default:
+		return zero, fmt.Errorf("dicom: unsupported GetValue type %T", zero)
+	}
+
+	typed, ok := v.GetValue().(T)
+	if !ok {
+		return zero, fmt.Errorf("dicom: cannot represent %v value as %T", v.ValueType(), zero)
+	}
+	return typed, nil
+}
+
+// MustGetValue is like GetValue but panics instead of returning an error. It is intended for tests and
+// other scenarios where the caller already knows the ValueType of v.
+func MustGetValue[T any](v Value) T {
+	val, err := GetValue[T](v)
+	if err != nil {
+		log.Panic(err)
+	}
+	return val
+}
+
+// AsStrings is a convenience wrapper around MustGetValue[[]string]. It is named with an "As" prefix,
+// rather than Strings, because Strings is already the name of the Strings ValueType constant.
+func AsStrings(v Value) []string { return MustGetValue[[]string](v) }
+
+// AsInts is a convenience wrapper around MustGetValue[[]int].
+func AsInts(v Value) []int { return MustGetValue[[]int](v) }
+
+// AsBytes is a convenience wrapper around MustGetValue[[]byte].
+func AsBytes(v Value) []byte { return MustGetValue[[]byte](v) }
+
+// AsPixelData is a convenience wrapper around MustGetValue[PixelDataInfo].
+func AsPixelData(v Value) PixelDataInfo { return MustGetValue[PixelDataInfo](v) }
+
+// AsSequence is a convenience wrapper around MustGetValue[[][]*Element].
+func AsSequence(v Value) [][]*Element { return MustGetValue[[][]*Element](v) }
+
+// FindElement finds the element tagged t in ds and extracts its value as T in one step, combining
+// FindElementByTag with GetValue:
+//
+//	patientName, err := dicom.FindElement[[]string](ds, tag.PatientName)
+func FindElement[T any](ds *Dataset, t tag.Tag) (T, error) {
+	var zero T
+	elem, err := FindElementByTag(ds, t)
+	if err != nil {
+		return zero, err
+	}
+	return GetValue[T](elem.Value)
+}
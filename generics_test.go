@@ -0,0 +1,79 @@
+package dicom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/suyashkumar/dicom/pkg/tag"
+)
+
+func TestGetValue(t *testing.T) {
+	strVal, err := NewValue([]string{"hello"})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	got, err := GetValue[[]string](strVal)
+	if err != nil {
+		t.Fatalf("GetValue[[]string] returned error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "hello" {
+		t.Errorf("got %v, want [hello]", got)
+	}
+
+	if _, err := GetValue[[]int](strVal); err == nil {
+		t.Error("GetValue[[]int] on a Strings value: expected an error, got none")
+	}
+}
+
+func TestGetValue_Sequence(t *testing.T) {
+	child := &Element{Tag: tag.Tag{Group: 1, Element: 1}, RawValueRepresentation: "SH"}
+	child.Value, _ = NewValue([]string{"child"})
+	seqVal, err := NewValue([][]*Element{{child}})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+
+	seq, err := GetValue[[][]*Element](seqVal)
+	if err != nil {
+		t.Fatalf("GetValue[[][]*Element] returned error: %v", err)
+	}
+	if len(seq) != 1 || len(seq[0]) != 1 {
+		t.Fatalf("got %v, want a single item with a single element", seq)
+	}
+	if got := MustGetStrings(seq[0][0].Value); got[0] != "child" {
+		t.Errorf("got %v, want [child]", got)
+	}
+}
+
+func TestMustGetValue_Panics(t *testing.T) {
+	strVal, _ := NewValue([]string{"hello"})
+	defer func() {
+		if recover() == nil {
+			t.Error("MustGetValue[[]int] on a Strings value: expected a panic, got none")
+		}
+	}()
+	MustGetValue[[]int](strVal)
+}
+
+func TestFindElement(t *testing.T) {
+	wantTag := tag.Tag{Group: 0x0010, Element: 0x0010}
+	elemValue, err := NewValue([]string{"Doe^John"})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	ds := &Dataset{Elements: []*Element{
+		{Tag: wantTag, RawValueRepresentation: "PN", Value: elemValue},
+	}}
+
+	got, err := FindElement[[]string](ds, wantTag)
+	if err != nil {
+		t.Fatalf("FindElement returned error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "Doe^John" {
+		t.Errorf("got %v, want [Doe^John]", got)
+	}
+
+	if _, err := FindElement[[]string](ds, tag.Tag{Group: 0x9999, Element: 0x9999}); !errors.Is(err, ErrorElementNotFound) {
+		t.Errorf("FindElement for a missing tag: got error %v, want ErrorElementNotFound", err)
+	}
+}
@@ -0,0 +1,486 @@
+// Package dicomjson implements the DICOM JSON Model described in PS3.18 Annex F
+// (http://dicom.nema.org/medical/dicom/current/output/html/part18.html#chapter_F), as an alternative to
+// the ad-hoc JSON shape that dicom.Element and dicom.Value emit via their own MarshalJSON methods.
+//
+// The API deliberately mirrors protobuf's jsonpb package: a configurable Marshaler converts a
+// dicom.Dataset to/from the DICOM JSON Model, so that this library's output can interoperate with
+// DICOMweb services (QIDO-RS/WADO-RS) that speak PS3.18 JSON natively.
+package dicomjson
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/suyashkumar/dicom"
+	"github.com/suyashkumar/dicom/pkg/frame"
+	"github.com/suyashkumar/dicom/pkg/tag"
+)
+
+// Marshaler converts a dicom.Dataset to/from the DICOM JSON Model (PS3.18 Annex F). The zero value is a
+// usable Marshaler with no indentation that always inlines binary values.
+type Marshaler struct {
+	// Indent, if non-empty, is used as the per-level indentation string when writing JSON (passed to
+	// json.Encoder.SetIndent). If empty, Marshal writes compact JSON.
+	Indent string
+	// BulkDataURITemplate is expanded via fmt.Sprintf (with the element's eight-character hex tag as the
+	// single %s argument) to produce the "BulkDataURI" for binary values that exceed
+	// InlineBinaryThreshold. If empty, binary values are always inlined, and Marshal returns an error for
+	// any binary value it cannot inline (e.g. encapsulated PixelData).
+	BulkDataURITemplate string
+	// InlineBinaryThreshold is the largest number of bytes a binary value (OB/OW/OF/UN/PixelData) may
+	// have before Marshal renders it as "BulkDataURI" instead of "InlineBinary". It only takes effect when
+	// BulkDataURITemplate is set; then zero renders every non-empty binary value as "BulkDataURI".
+	InlineBinaryThreshold int
+	// OmitGroupLength excludes group length elements (gggg,0000) from the marshaled output, as
+	// recommended by PS3.18 Annex F.2.7.
+	OmitGroupLength bool
+	// IncludePrivateTags includes private (odd group number) elements in the marshaled output. Private
+	// elements are omitted by default, since their VR and meaning are not defined by the standard.
+	IncludePrivateTags bool
+}
+
+// jsonElement is the DICOM JSON Model representation of a single dicom.Element, keyed by its eight
+// character uppercase hex tag in the enclosing map (see marshalDataset/unmarshalDataset).
+type jsonElement struct {
+	VR           string            `json:"vr"`
+	Value        []json.RawMessage `json:"Value,omitempty"`
+	InlineBinary string            `json:"InlineBinary,omitempty"`
+	BulkDataURI  string            `json:"BulkDataURI,omitempty"`
+}
+
+// personNameComponents is the DICOM JSON Model representation of a single PN value (PS3.18 F.2.2).
+type personNameComponents struct {
+	Alphabetic  string `json:"Alphabetic,omitempty"`
+	Ideographic string `json:"Ideographic,omitempty"`
+	Phonetic    string `json:"Phonetic,omitempty"`
+}
+
+// Marshal writes ds to w in the DICOM JSON Model.
+func (m *Marshaler) Marshal(w io.Writer, ds *dicom.Dataset) error {
+	out, err := m.marshalDataset(ds)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	if m.Indent != "" {
+		enc.SetIndent("", m.Indent)
+	}
+	return enc.Encode(out)
+}
+
+func (m *Marshaler) marshalDataset(ds *dicom.Dataset) (map[string]*jsonElement, error) {
+	out := make(map[string]*jsonElement, len(ds.Elements))
+	for _, elem := range ds.Elements {
+		if m.OmitGroupLength && elem.Tag.Element == 0x0000 {
+			continue
+		}
+		if !m.IncludePrivateTags && elem.Tag.Group%2 != 0 {
+			continue
+		}
+		je, err := m.marshalElement(elem)
+		if err != nil {
+			return nil, err
+		}
+		out[tagKey(elem.Tag)] = je
+	}
+	return out, nil
+}
+
+func (m *Marshaler) marshalElement(elem *dicom.Element) (*jsonElement, error) {
+	switch elem.Value.ValueType() {
+	case dicom.PixelData:
+		return m.marshalPixelData(elem)
+	case dicom.Sequences:
+		return m.marshalSequence(elem)
+	case dicom.Bytes:
+		return m.marshalBinary(elem, elem.Value.GetValue().([]byte))
+	case dicom.Ints:
+		return marshalInts(elem, elem.Value.GetValue().([]int))
+	case dicom.Strings:
+		return marshalStrings(elem, elem.Value.GetValue().([]string))
+	default:
+		return nil, fmt.Errorf("dicomjson: tag %s has unsupported ValueType %v", elem.Tag.String(), elem.Value.ValueType())
+	}
+}
+
+func marshalInts(elem *dicom.Element, ints []int) (*jsonElement, error) {
+	values := make([]json.RawMessage, len(ints))
+	for i, n := range ints {
+		var v interface{} = n
+		if elem.RawValueRepresentation == "AT" {
+			// PS3.18 Annex F.2.3.1 renders AT as an 8 character uppercase hex string, unlike the other
+			// integer VRs (US/SS/UL/SL), which are plain JSON numbers.
+			v = atHex(n)
+		}
+		raw, err := toRaw(v)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = raw
+	}
+	return &jsonElement{VR: elem.RawValueRepresentation, Value: values}, nil
+}
+
+// atHex renders an AT value as the 8 character uppercase hex string PS3.18 requires, the same format
+// tagKey uses for the map keys themselves.
+func atHex(n int) string {
+	return strings.ToUpper(fmt.Sprintf("%08x", uint32(n)))
+}
+
+func marshalStrings(elem *dicom.Element, strs []string) (*jsonElement, error) {
+	values := make([]json.RawMessage, 0, len(strs))
+	for _, s := range strs {
+		var (
+			v   interface{}
+			err error
+		)
+		switch elem.RawValueRepresentation {
+		case "PN":
+			v = marshalPersonName(s)
+		case "IS", "DS", "FL", "FD":
+			v, err = strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return nil, fmt.Errorf("dicomjson: tag %s has non-numeric %s value %q: %w", elem.Tag.String(), elem.RawValueRepresentation, s, err)
+			}
+		default:
+			v = s
+		}
+		raw, err := toRaw(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, raw)
+	}
+	return &jsonElement{VR: elem.RawValueRepresentation, Value: values}, nil
+}
+
+// marshalPersonName splits a raw PN value on "=" into its Alphabetic/Ideographic/Phonetic component
+// groups, per PS3.5 Section 6.2.1.
+func marshalPersonName(raw string) personNameComponents {
+	groups := strings.SplitN(raw, "=", 3)
+	var pn personNameComponents
+	if len(groups) > 0 {
+		pn.Alphabetic = groups[0]
+	}
+	if len(groups) > 1 {
+		pn.Ideographic = groups[1]
+	}
+	if len(groups) > 2 {
+		pn.Phonetic = groups[2]
+	}
+	return pn
+}
+
+func (m *Marshaler) marshalBinary(elem *dicom.Element, data []byte) (*jsonElement, error) {
+	je := &jsonElement{VR: elem.RawValueRepresentation}
+	if m.BulkDataURITemplate != "" && len(data) > m.InlineBinaryThreshold {
+		je.BulkDataURI = fmt.Sprintf(m.BulkDataURITemplate, tagKey(elem.Tag))
+		return je, nil
+	}
+	je.InlineBinary = base64.StdEncoding.EncodeToString(data)
+	return je, nil
+}
+
+func (m *Marshaler) marshalPixelData(elem *dicom.Element) (*jsonElement, error) {
+	je := &jsonElement{VR: elem.RawValueRepresentation}
+	info := elem.Value.GetValue().(dicom.PixelDataInfo)
+	data, inlineable := flattenNativePixelData(info)
+	if !inlineable || (m.BulkDataURITemplate != "" && len(data) > m.InlineBinaryThreshold) {
+		if m.BulkDataURITemplate == "" {
+			return nil, fmt.Errorf("dicomjson: tag %s holds encapsulated or non-packable PixelData and requires BulkDataURITemplate to marshal", elem.Tag.String())
+		}
+		je.BulkDataURI = fmt.Sprintf(m.BulkDataURITemplate, tagKey(elem.Tag))
+		return je, nil
+	}
+	je.InlineBinary = base64.StdEncoding.EncodeToString(data)
+	return je, nil
+}
+
+// flattenNativePixelData concatenates the raw samples of every native (non-encapsulated) frame into a
+// single little-endian byte slice suitable for InlineBinary, packing each sample into the number of
+// bytes its BitsPerSample requires. It returns false if info is encapsulated, or if any frame uses a
+// BitsPerSample this function does not know how to pack, since such PixelData must be rendered via
+// BulkDataURI instead of being silently truncated.
+func flattenNativePixelData(info dicom.PixelDataInfo) ([]byte, bool) {
+	if info.IsEncapsulated {
+		return nil, false
+	}
+	var out []byte
+	for _, f := range info.Frames {
+		nativeFrame, err := f.GetNativeFrame()
+		if err != nil {
+			return nil, false
+		}
+		bytesPerSample := nativeFrame.BitsPerSample / 8
+		switch bytesPerSample {
+		case 1, 2:
+		default:
+			return nil, false
+		}
+		for _, sample := range nativeFrame.Data {
+			for _, v := range sample {
+				for i := 0; i < bytesPerSample; i++ {
+					out = append(out, byte(v>>(8*i)))
+				}
+			}
+		}
+	}
+	return out, true
+}
+
+func (m *Marshaler) marshalSequence(elem *dicom.Element) (*jsonElement, error) {
+	items, ok := elem.Value.GetValue().([]*dicom.SequenceItemValue)
+	if !ok {
+		return nil, fmt.Errorf("dicomjson: tag %s has unexpected sequence value type %T", elem.Tag.String(), elem.Value.GetValue())
+	}
+	values := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		elements, ok := item.GetValue().([]*dicom.Element)
+		if !ok {
+			return nil, fmt.Errorf("dicomjson: tag %s has unexpected sequence item value type %T", elem.Tag.String(), item.GetValue())
+		}
+		nested, err := m.marshalDataset(&dicom.Dataset{Elements: elements})
+		if err != nil {
+			return nil, err
+		}
+		raw, err := toRaw(nested)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, raw)
+	}
+	return &jsonElement{VR: elem.RawValueRepresentation, Value: values}, nil
+}
+
+func toRaw(v interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+func tagKey(t tag.Tag) string {
+	return strings.ToUpper(fmt.Sprintf("%04x%04x", t.Group, t.Element))
+}
+
+// Unmarshal reads a DICOM JSON Model document from r and populates ds with the elements it describes,
+// replacing any elements ds already holds. Unmarshal does not fetch "BulkDataURI" values; elements
+// rendered that way return an error, since resolving them requires a DICOMweb client that is outside the
+// scope of this package.
+func (m *Marshaler) Unmarshal(r io.Reader, ds *dicom.Dataset) error {
+	var raw map[string]*jsonElement
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return err
+	}
+	elements, err := unmarshalElements(raw)
+	if err != nil {
+		return err
+	}
+	ds.Elements = elements
+	return nil
+}
+
+// unmarshalElements converts a decoded DICOM JSON Model object into []*dicom.Element, sorted by tag so
+// that output is deterministic.
+func unmarshalElements(raw map[string]*jsonElement) ([]*dicom.Element, error) {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	elements := make([]*dicom.Element, 0, len(keys))
+	for _, key := range keys {
+		elem, err := unmarshalElement(key, raw[key])
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, elem)
+	}
+	return elements, nil
+}
+
+func unmarshalElement(tagHex string, je *jsonElement) (*dicom.Element, error) {
+	t, err := parseTagHex(tagHex)
+	if err != nil {
+		return nil, err
+	}
+	if je.BulkDataURI != "" {
+		return nil, fmt.Errorf("dicomjson: tag %s uses BulkDataURI %q, which Unmarshal cannot resolve", tagHex, je.BulkDataURI)
+	}
+
+	data, err := unmarshalValue(t, je)
+	if err != nil {
+		return nil, err
+	}
+	value, err := dicom.NewValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return &dicom.Element{
+		Tag:                    t,
+		ValueRepresentation:    tag.GetVRKind(t, je.VR),
+		RawValueRepresentation: je.VR,
+		Value:                  value,
+	}, nil
+}
+
+func unmarshalValue(t tag.Tag, je *jsonElement) (interface{}, error) {
+	switch je.VR {
+	case "SQ":
+		return unmarshalSequence(je)
+	case "OB", "OW", "OF", "UN":
+		if isPixelDataTag(t) {
+			return unmarshalPixelData(je)
+		}
+		return unmarshalInlineBinary(je)
+	case "US", "SS", "UL", "SL":
+		return unmarshalInts(je)
+	case "AT":
+		return unmarshalATInts(je)
+	case "PN":
+		return unmarshalPersonNames(je)
+	default:
+		return unmarshalStrings(je)
+	}
+}
+
+func unmarshalSequence(je *jsonElement) ([][]*dicom.Element, error) {
+	items := make([][]*dicom.Element, 0, len(je.Value))
+	for _, raw := range je.Value {
+		var nested map[string]*jsonElement
+		if err := json.Unmarshal(raw, &nested); err != nil {
+			return nil, fmt.Errorf("dicomjson: invalid sequence item: %w", err)
+		}
+		elements, err := unmarshalElements(nested)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, elements)
+	}
+	return items, nil
+}
+
+func unmarshalInlineBinary(je *jsonElement) ([]byte, error) {
+	if je.InlineBinary == "" {
+		return []byte{}, nil
+	}
+	return base64.StdEncoding.DecodeString(je.InlineBinary)
+}
+
+// unmarshalPixelData reconstructs a minimal dicom.PixelDataInfo from an InlineBinary value, treating the
+// decoded bytes as a single native (uncompressed), byte-packed frame.
+func unmarshalPixelData(je *jsonElement) (dicom.PixelDataInfo, error) {
+	data, err := unmarshalInlineBinary(je)
+	if err != nil {
+		return dicom.PixelDataInfo{}, err
+	}
+	samples := make([][]int, len(data))
+	for i, b := range data {
+		samples[i] = []int{int(b)}
+	}
+	return dicom.PixelDataInfo{
+		Frames: []frame.Frame{{
+			Encapsulated: false,
+			NativeData: frame.NativeFrame{
+				Data:          samples,
+				BitsPerSample: 8,
+			},
+		}},
+	}, nil
+}
+
+func unmarshalInts(je *jsonElement) ([]int, error) {
+	out := make([]int, len(je.Value))
+	for i, raw := range je.Value {
+		var f float64
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, fmt.Errorf("dicomjson: expected a number, got %s: %w", raw, err)
+		}
+		out[i] = int(f)
+	}
+	return out, nil
+}
+
+// unmarshalATInts parses AT values back from the 8 character uppercase hex strings PS3.18 Annex F.2.3.1
+// requires for them, the inverse of atHex.
+func unmarshalATInts(je *jsonElement) ([]int, error) {
+	out := make([]int, len(je.Value))
+	for i, raw := range je.Value {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("dicomjson: expected an 8 character hex string for AT, got %s: %w", raw, err)
+		}
+		n, err := strconv.ParseUint(s, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("dicomjson: %q is not a valid AT hex value: %w", s, err)
+		}
+		out[i] = int(n)
+	}
+	return out, nil
+}
+
+func unmarshalPersonNames(je *jsonElement) ([]string, error) {
+	out := make([]string, len(je.Value))
+	for i, raw := range je.Value {
+		var pn personNameComponents
+		if err := json.Unmarshal(raw, &pn); err != nil {
+			return nil, fmt.Errorf("dicomjson: invalid PN value %s: %w", raw, err)
+		}
+		switch {
+		case pn.Phonetic != "":
+			out[i] = strings.Join([]string{pn.Alphabetic, pn.Ideographic, pn.Phonetic}, "=")
+		case pn.Ideographic != "":
+			out[i] = strings.Join([]string{pn.Alphabetic, pn.Ideographic}, "=")
+		default:
+			out[i] = pn.Alphabetic
+		}
+	}
+	return out, nil
+}
+
+func unmarshalStrings(je *jsonElement) ([]string, error) {
+	out := make([]string, len(je.Value))
+	for i, raw := range je.Value {
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		switch t := v.(type) {
+		case string:
+			out[i] = t
+		case float64:
+			out[i] = strconv.FormatFloat(t, 'g', -1, 64)
+		default:
+			return nil, fmt.Errorf("dicomjson: unexpected Value element %v of type %T", v, v)
+		}
+	}
+	return out, nil
+}
+
+// isPixelDataTag reports whether t is the (7FE0,0010) PixelData tag, the one element the DICOM JSON
+// Model allows to carry either native or encapsulated binary data.
+func isPixelDataTag(t tag.Tag) bool {
+	return t.Group == 0x7FE0 && t.Element == 0x0010
+}
+
+func parseTagHex(tagHex string) (tag.Tag, error) {
+	if len(tagHex) != 8 {
+		return tag.Tag{}, fmt.Errorf("dicomjson: %q is not a valid 8 character hex tag", tagHex)
+	}
+	group, err := strconv.ParseUint(tagHex[:4], 16, 32)
+	if err != nil {
+		return tag.Tag{}, fmt.Errorf("dicomjson: %q is not a valid 8 character hex tag: %w", tagHex, err)
+	}
+	elem, err := strconv.ParseUint(tagHex[4:], 16, 32)
+	if err != nil {
+		return tag.Tag{}, fmt.Errorf("dicomjson: %q is not a valid 8 character hex tag: %w", tagHex, err)
+	}
+	return tag.Tag{Group: uint16(group), Element: uint16(elem)}, nil
+}
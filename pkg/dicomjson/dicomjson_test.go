@@ -0,0 +1,184 @@
+package dicomjson
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	dicom "github.com/suyashkumar/dicom"
+	"github.com/suyashkumar/dicom/pkg/frame"
+	"github.com/suyashkumar/dicom/pkg/tag"
+)
+
+func mustNewElement(t *testing.T, tg tag.Tag, vr string, data interface{}) *dicom.Element {
+	t.Helper()
+	v, err := dicom.NewValueForVR(vr, data)
+	if err != nil {
+		t.Fatalf("NewValueForVR(%q, %v) returned error: %v", vr, data, err)
+	}
+	return &dicom.Element{
+		Tag:                    tg,
+		RawValueRepresentation: vr,
+		ValueRepresentation:    tag.GetVRKind(tg, vr),
+		Value:                  v,
+	}
+}
+
+func TestMarshalUnmarshal_RoundTrip(t *testing.T) {
+	patientName := mustNewElement(t, tag.Tag{Group: 0x0010, Element: 0x0010}, "PN", []dicom.PersonName{{Alphabetic: "Doe^John"}})
+
+	child := mustNewElement(t, tag.Tag{Group: 0x0008, Element: 0x0100}, "SH", []string{"CODE1"})
+	seqVal, err := dicom.NewValue([][]*dicom.Element{{child}})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	seqElem := &dicom.Element{Tag: tag.Tag{Group: 0x0040, Element: 0xA168}, RawValueRepresentation: "SQ", Value: seqVal}
+
+	at := mustNewElement(t, tag.Tag{Group: 0x0054, Element: 0x0220}, "AT", []int{1234})
+
+	ds := &dicom.Dataset{Elements: []*dicom.Element{patientName, seqElem, at}}
+
+	m := &Marshaler{}
+	var buf bytes.Buffer
+	if err := m.Marshal(&buf, ds); err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	// PS3.18 Annex F.2.3.1 requires AT to be rendered as an 8 character uppercase hex string, not a
+	// JSON number.
+	if !strings.Contains(buf.String(), `"Value":["000004D2"]`) {
+		t.Errorf("AT not rendered as an 8 character hex string: %s", buf.String())
+	}
+
+	var got dicom.Dataset
+	if err := m.Unmarshal(bytes.NewReader(buf.Bytes()), &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	names, err := dicom.FindElement[[]string](&got, tag.Tag{Group: 0x0010, Element: 0x0010})
+	if err != nil {
+		t.Fatalf("FindElement(PatientName) returned error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "Doe^John" {
+		t.Errorf("PatientName round-tripped as %v, want [Doe^John]", names)
+	}
+
+	seq, err := dicom.FindElement[[][]*dicom.Element](&got, tag.Tag{Group: 0x0040, Element: 0xA168})
+	if err != nil {
+		t.Fatalf("FindElement(sequence) returned error: %v", err)
+	}
+	if len(seq) != 1 || len(seq[0]) != 1 || dicom.MustGetStrings(seq[0][0].Value)[0] != "CODE1" {
+		t.Errorf("sequence round-tripped as %+v, want a single CODE1 item", seq)
+	}
+
+	// AT's ValueType is Ints (like US/UL/SL); it must round-trip as ints, not regress into a string, or
+	// ValidateElement will flag it as a VR mismatch.
+	atElem, err := dicom.FindElementByTag(&got, tag.Tag{Group: 0x0054, Element: 0x0220})
+	if err != nil {
+		t.Fatalf("FindElementByTag(AT) returned error: %v", err)
+	}
+	if atElem.Value.ValueType() != dicom.Ints {
+		t.Errorf("AT round-tripped with ValueType %v, want Ints", atElem.Value.ValueType())
+	}
+	if err := dicom.ValidateElement(atElem); err != nil {
+		t.Errorf("ValidateElement(round-tripped AT) = %v, want nil", err)
+	}
+}
+
+func TestMarshal_PixelData(t *testing.T) {
+	// 16-bit samples must be packed little-endian, not truncated to their low byte.
+	info := dicom.PixelDataInfo{Frames: []frame.Frame{{
+		NativeData: frame.NativeFrame{Data: [][]int{{0x0102}}, BitsPerSample: 16},
+	}}}
+	v, err := dicom.NewValue(info)
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	elem := &dicom.Element{Tag: tag.Tag{Group: 0x7FE0, Element: 0x0010}, RawValueRepresentation: "OW", Value: v}
+	ds := &dicom.Dataset{Elements: []*dicom.Element{elem}}
+
+	m := &Marshaler{}
+	var buf bytes.Buffer
+	if err := m.Marshal(&buf, ds); err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	// 0x0102 packed little-endian is {0x02, 0x01}, which base64-encodes to "AgE=".
+	if !strings.Contains(buf.String(), `"InlineBinary":"AgE="`) {
+		t.Fatalf("expected InlineBinary \"AgE=\", got %s", buf.String())
+	}
+
+	var got dicom.Dataset
+	if err := m.Unmarshal(bytes.NewReader(buf.Bytes()), &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	gotInfo := dicom.MustGetValue[dicom.PixelDataInfo](got.Elements[0].Value)
+	if len(gotInfo.Frames) != 1 {
+		t.Fatalf("got %d frames, want 1", len(gotInfo.Frames))
+	}
+}
+
+func TestMarshal_EncapsulatedPixelDataRequiresBulkDataURITemplate(t *testing.T) {
+	v, err := dicom.NewValue(dicom.PixelDataInfo{
+		IsEncapsulated: true,
+		Frames:         []frame.Frame{{Encapsulated: true, EncapsulatedData: frame.EncapsulatedFrame{Data: []byte{1, 2, 3}}}},
+	})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	elem := &dicom.Element{Tag: tag.Tag{Group: 0x7FE0, Element: 0x0010}, RawValueRepresentation: "OB", Value: v}
+	ds := &dicom.Dataset{Elements: []*dicom.Element{elem}}
+
+	m := &Marshaler{}
+	if err := m.Marshal(&bytes.Buffer{}, ds); err == nil {
+		t.Error("Marshal with no BulkDataURITemplate: expected an error, got none")
+	}
+
+	m = &Marshaler{BulkDataURITemplate: "http://example.com/bulk/%s"}
+	var buf bytes.Buffer
+	if err := m.Marshal(&buf, ds); err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"BulkDataURI":"http://example.com/bulk/7FE00010"`) {
+		t.Errorf("got %s, want a BulkDataURI referencing the tag", buf.String())
+	}
+}
+
+func TestUnmarshal_BulkDataURIIsUnsupported(t *testing.T) {
+	m := &Marshaler{}
+	err := m.Unmarshal(strings.NewReader(`{"7FE00010":{"vr":"OB","BulkDataURI":"http://example.com/x"}}`), &dicom.Dataset{})
+	if err == nil {
+		t.Error("Unmarshal of a BulkDataURI element: expected an error, got none")
+	}
+}
+
+func TestUnmarshal_InvalidTag(t *testing.T) {
+	m := &Marshaler{}
+	err := m.Unmarshal(strings.NewReader(`{"nottag":{"vr":"SH","Value":["x"]}}`), &dicom.Dataset{})
+	if err == nil {
+		t.Error("Unmarshal of a malformed tag key: expected an error, got none")
+	}
+}
+
+func TestMarshal_PrivateTagsOmittedByDefault(t *testing.T) {
+	v, _ := dicom.NewValue([]string{"secret"})
+	elem := &dicom.Element{Tag: tag.Tag{Group: 0x0009, Element: 0x0010}, RawValueRepresentation: "LO", Value: v}
+	ds := &dicom.Dataset{Elements: []*dicom.Element{elem}}
+
+	m := &Marshaler{}
+	var buf bytes.Buffer
+	if err := m.Marshal(&buf, ds); err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("private tag leaked into output: %s", buf.String())
+	}
+
+	m = &Marshaler{IncludePrivateTags: true}
+	buf.Reset()
+	if err := m.Marshal(&buf, ds); err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "secret") {
+		t.Errorf("IncludePrivateTags did not include the private tag: %s", buf.String())
+	}
+}
@@ -0,0 +1,228 @@
+package dicom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrValueOutOfRange is returned by NewValueForVR when an integer value does not fit within the range
+// allowed by the target VR (e.g. a value larger than 65535 for US).
+var ErrValueOutOfRange = errors.New("dicom: value out of range for VR")
+
+// ErrVRMismatch is returned by NewValueForVR when the Go type of the supplied data cannot represent the
+// target VR, and by ValidateElement when a stored Element's Go type does not match its declared VR.
+var ErrVRMismatch = errors.New("dicom: value type does not match VR")
+
+// PersonName represents the component groups of a DICOM PN (Person Name) value (PS3.5 Section 6.2.1).
+// Each component is itself a "^"-delimited FamilyName^GivenName^MiddleName^Prefix^Suffix group; this
+// struct only separates the three representations ("=" delimited) that the standard allows for a single
+// name, not the "^" delimited parts within each.
+type PersonName struct {
+	Alphabetic  string
+	Ideographic string
+	Phonetic    string
+}
+
+// String renders the PersonName into its "="-delimited raw DICOM string form.
+func (p PersonName) String() string {
+	switch {
+	case p.Phonetic != "":
+		return strings.Join([]string{p.Alphabetic, p.Ideographic, p.Phonetic}, "=")
+	case p.Ideographic != "":
+		return strings.Join([]string{p.Alphabetic, p.Ideographic}, "=")
+	default:
+		return p.Alphabetic
+	}
+}
+
+// NewValueForVR is like NewValue, but additionally takes the target VR so it can accept richer input
+// types and validate them before they are stored on an Element:
+//
+//   - IS and DS accept []string, []int, or []float64, and are normalized into the canonical []string form.
+//   - US, UL, and AT accept []int and reject values outside their respective unsigned range (ErrValueOutOfRange).
+//   - SS and SL accept []int and reject values outside their signed range (ErrValueOutOfRange).
+//   - PN accepts []PersonName (or []string, for a pre-formatted raw value) and renders each into its
+//     "="-delimited string form.
+//   - DA, DT, and TM accept []time.Time and format each per the DICOM spec.
+//
+// A VR listed above given data of any other type returns ErrVRMismatch. Any other VR is handled by NewValue.
+func NewValueForVR(vr string, data interface{}) (Value, error) {
+	switch vr {
+	case "IS", "DS":
+		return newNumericStringsValue(vr, data)
+	case "US":
+		return newRangedIntsValue(vr, data, 0, math.MaxUint16)
+	case "UL", "AT":
+		return newRangedIntsValue(vr, data, 0, math.MaxUint32)
+	case "SS":
+		return newRangedIntsValue(vr, data, math.MinInt16, math.MaxInt16)
+	case "SL":
+		return newRangedIntsValue(vr, data, math.MinInt32, math.MaxInt32)
+	case "PN":
+		return newPersonNameValue(data)
+	case "DA", "DT", "TM":
+		return newDateTimeValue(vr, data)
+	default:
+		return NewValue(data)
+	}
+}
+
+func newNumericStringsValue(vr string, data interface{}) (Value, error) {
+	switch v := data.(type) {
+	case []string:
+		return &stringsValue{value: v}, nil
+	case []int:
+		out := make([]string, len(v))
+		for i, n := range v {
+			out[i] = strconv.Itoa(n)
+		}
+		return &stringsValue{value: out}, nil
+	case []float64:
+		out := make([]string, len(v))
+		for i, f := range v {
+			out[i] = strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return &stringsValue{value: out}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s expects []string, []int, or []float64, got %T", ErrVRMismatch, vr, data)
+	}
+}
+
+// newRangedIntsValue validates that every element of data (which must be []int) falls within [min, max]
+// before wrapping it as an intsValue. US/UL/AT share an unsigned range, while SS/SL have a signed one.
+func newRangedIntsValue(vr string, data interface{}, min, max int64) (Value, error) {
+	ints, ok := data.([]int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s expects []int, got %T", ErrVRMismatch, vr, data)
+	}
+	for _, n := range ints {
+		if int64(n) < min || int64(n) > max {
+			return nil, fmt.Errorf("%w: %s value %d must be in [%d, %d]", ErrValueOutOfRange, vr, n, min, max)
+		}
+	}
+	return &intsValue{value: ints}, nil
+}
+
+// newPersonNameValue accepts []PersonName, rendering each into its raw "="-delimited string form. It
+// also accepts []string for backward compatibility with NewValue, treating each string as an
+// already-formatted raw DICOM PN value.
+func newPersonNameValue(data interface{}) (Value, error) {
+	switch v := data.(type) {
+	case []PersonName:
+		out := make([]string, len(v))
+		for i, n := range v {
+			out[i] = n.String()
+		}
+		return &stringsValue{value: out}, nil
+	case []string:
+		return &stringsValue{value: v}, nil
+	default:
+		return nil, fmt.Errorf("%w: PN expects []PersonName or []string, got %T", ErrVRMismatch, data)
+	}
+}
+
+func newDateTimeValue(vr string, data interface{}) (Value, error) {
+	times, ok := data.([]time.Time)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s expects []time.Time, got %T", ErrVRMismatch, vr, data)
+	}
+	layout := dateTimeLayout(vr)
+	out := make([]string, len(times))
+	for i, t := range times {
+		out[i] = t.Format(layout)
+	}
+	return &stringsValue{value: out}, nil
+}
+
+// dateTimeLayout returns the Golang reference-time layout matching the DICOM encoding of vr (PS3.5
+// Section 6.2).
+func dateTimeLayout(vr string) string {
+	switch vr {
+	case "DA":
+		return "20060102"
+	case "TM":
+		return "150405.000000"
+	default: // DT
+		return "20060102150405.000000-0700"
+	}
+}
+
+// ValidateElement reports every element in elem (recursing into nested elements if elem is a sequence)
+// whose stored Go type does not match its declared VR, joining them into a single error. It returns nil
+// if elem and all of its descendants are consistent. Only VRs with a single canonical ValueType (see
+// expectedValueTypeForVR) and PixelData are checked; elements with any other VR are not reported, and
+// the declared VR of an element holding a sequence is not checked. Callers typically use this as a pre-write sanity
+// pass over an entire Dataset:
+//
+//	for _, elem := range ds.Elements {
+//		if err := dicom.ValidateElement(elem); err != nil {
+//			// handle/report err
+//		}
+//	}
+func ValidateElement(elem *Element) error {
+	var errs []error
+	validateElement(elem, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return vrMismatchErrors(errs)
+}
+
+func validateElement(elem *Element, errs *[]error) {
+	switch elem.Value.ValueType() {
+	case Sequences:
+		for _, item := range elem.Value.GetValue().([]*SequenceItemValue) {
+			for _, child := range item.GetValue().([]*Element) {
+				validateElement(child, errs)
+			}
+		}
+		return
+	case PixelData:
+		// PixelData is always declared as OB or OW, regardless of the transfer syntax's native VR.
+		if elem.RawValueRepresentation != "OB" && elem.RawValueRepresentation != "OW" {
+			*errs = append(*errs, fmt.Errorf("%w: tag %s declared as %s but holds PixelData", ErrVRMismatch, elem.Tag.String(), elem.RawValueRepresentation))
+		}
+		return
+	}
+	expected, ok := expectedValueTypeForVR(elem.RawValueRepresentation)
+	if !ok {
+		return
+	}
+	if elem.Value.ValueType() != expected {
+		*errs = append(*errs, fmt.Errorf("%w: tag %s declared as %s but holds a %v value", ErrVRMismatch, elem.Tag.String(), elem.RawValueRepresentation, elem.Value.ValueType()))
+	}
+}
+
+// expectedValueTypeForVR reports the ValueType that newElement/NewValueForVR stores an Element's Value as
+// for vr, if this package enforces a single canonical ValueType for it.
+func expectedValueTypeForVR(vr string) (ValueType, bool) {
+	switch vr {
+	case "US", "SS", "UL", "SL", "AT":
+		return Ints, true
+	case "IS", "DS", "PN", "DA", "DT", "TM":
+		return Strings, true
+	case "OB", "OW", "OF", "UN":
+		return Bytes, true
+	case "SQ":
+		return Sequences, true
+	default:
+		return 0, false
+	}
+}
+
+// vrMismatchErrors joins errs into a single error whose message lists every mismatch found.
+type vrMismatchErrors []error
+
+func (e vrMismatchErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e vrMismatchErrors) Unwrap() []error { return e }
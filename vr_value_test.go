@@ -0,0 +1,192 @@
+package dicom
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/suyashkumar/dicom/pkg/tag"
+)
+
+func TestNewValueForVR_NumericStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		vr   string
+		data interface{}
+		want []string
+	}{
+		{"IS from []string", "IS", []string{"42"}, []string{"42"}},
+		{"IS from []int", "IS", []int{42}, []string{"42"}},
+		{"DS from []float64", "DS", []float64{3.5}, []string{"3.5"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := NewValueForVR(tc.vr, tc.data)
+			if err != nil {
+				t.Fatalf("NewValueForVR(%q, %v) returned error: %v", tc.vr, tc.data, err)
+			}
+			got := MustGetStrings(v)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("got[%d] = %q, want %q", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewValueForVR_IntRanges(t *testing.T) {
+	tests := []struct {
+		vr      string
+		data    []int
+		wantErr bool
+	}{
+		{"US", []int{0}, false},
+		{"US", []int{65535}, false},
+		{"US", []int{65536}, true},
+		{"US", []int{-1}, true},
+		{"SS", []int{-32768}, false},
+		{"SS", []int{32767}, false},
+		{"SS", []int{32768}, true},
+		{"UL", []int{4294967295}, false},
+		{"SL", []int{-2147483648}, false},
+		{"SL", []int{2147483648}, true},
+		{"AT", []int{4294967295}, false},
+	}
+	for _, tc := range tests {
+		v, err := NewValueForVR(tc.vr, tc.data)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("NewValueForVR(%q, %v): expected an error, got none", tc.vr, tc.data)
+			} else if !errors.Is(err, ErrValueOutOfRange) {
+				t.Errorf("NewValueForVR(%q, %v): got error %v, want ErrValueOutOfRange", tc.vr, tc.data, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewValueForVR(%q, %v): unexpected error: %v", tc.vr, tc.data, err)
+			continue
+		}
+		if got := MustGetInts(v); len(got) != 1 || got[0] != tc.data[0] {
+			t.Errorf("NewValueForVR(%q, %v): got %v", tc.vr, tc.data, got)
+		}
+	}
+}
+
+func TestNewValueForVR_PersonName(t *testing.T) {
+	v, err := NewValueForVR("PN", []PersonName{{Alphabetic: "Doe^John", Ideographic: "山田^太郎"}})
+	if err != nil {
+		t.Fatalf("NewValueForVR(PN, []PersonName) returned error: %v", err)
+	}
+	want := "Doe^John=山田^太郎"
+	if got := MustGetStrings(v)[0]; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Backward compatibility: newElement now routes through NewValueForVR instead of NewValue, so a
+	// plain []string (the only form NewValue ever accepted for PN) must keep working.
+	v, err = NewValueForVR("PN", []string{"Doe^John"})
+	if err != nil {
+		t.Fatalf("NewValueForVR(PN, []string) returned error: %v", err)
+	}
+	if got := MustGetStrings(v)[0]; got != "Doe^John" {
+		t.Errorf("got %q, want %q", got, "Doe^John")
+	}
+}
+
+func TestNewValueForVR_DateTime(t *testing.T) {
+	tests := []struct {
+		name string
+		vr   string
+		time time.Time
+		want string
+	}{
+		{"DA", "DA", time.Date(2020, 3, 4, 13, 45, 30, 0, time.UTC), "20200304"},
+		{"TM", "TM", time.Date(2020, 3, 4, 13, 45, 30, 123456000, time.UTC), "134530.123456"},
+		{"DT UTC", "DT", time.Date(2020, 3, 4, 13, 45, 30, 0, time.UTC), "20200304134530.000000+0000"},
+		{"DT non-UTC", "DT", time.Date(2020, 3, 4, 13, 45, 30, 0, time.FixedZone("", 5*60*60)), "20200304134530.000000+0500"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := NewValueForVR(tc.vr, []time.Time{tc.time})
+			if err != nil {
+				t.Fatalf("NewValueForVR(%q, %v) returned error: %v", tc.vr, tc.time, err)
+			}
+			if got := MustGetStrings(v)[0]; got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewValueForVR_DateTimeMismatch(t *testing.T) {
+	if _, err := NewValueForVR("DA", []string{"20200304"}); !errors.Is(err, ErrVRMismatch) {
+		t.Errorf("NewValueForVR(DA, []string): got error %v, want ErrVRMismatch", err)
+	}
+}
+
+func TestNewValueForVR_VRMismatch(t *testing.T) {
+	if _, err := NewValueForVR("US", []string{"not an int"}); !errors.Is(err, ErrVRMismatch) {
+		t.Errorf("NewValueForVR(US, []string): got error %v, want ErrVRMismatch", err)
+	}
+	if _, err := NewValueForVR("PN", []int{1}); !errors.Is(err, ErrVRMismatch) {
+		t.Errorf("NewValueForVR(PN, []int): got error %v, want ErrVRMismatch", err)
+	}
+}
+
+func TestValidateElement_PixelDataIsNotAMismatch(t *testing.T) {
+	v, err := NewValue(PixelDataInfo{})
+	if err != nil {
+		t.Fatalf("NewValue(PixelDataInfo{}) returned error: %v", err)
+	}
+	elem := &Element{
+		Tag:                    tag.Tag{Group: 0x7FE0, Element: 0x0010},
+		RawValueRepresentation: "OW",
+		Value:                  v,
+	}
+	if err := ValidateElement(elem); err != nil {
+		t.Errorf("ValidateElement(valid PixelData element) = %v, want nil", err)
+	}
+}
+
+func TestValidateElement_DetectsMismatch(t *testing.T) {
+	v, err := NewValue([]string{"oops"})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	elem := &Element{
+		Tag:                    tag.Tag{Group: 1, Element: 1},
+		RawValueRepresentation: "US",
+		Value:                  v,
+	}
+	if err := ValidateElement(elem); !errors.Is(err, ErrVRMismatch) {
+		t.Errorf("ValidateElement(mismatched element) = %v, want ErrVRMismatch", err)
+	}
+}
+
+func TestValidateElement_RecursesIntoSequences(t *testing.T) {
+	badChildValue, err := NewValue([]string{"oops"})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	badChild := &Element{
+		Tag:                    tag.Tag{Group: 1, Element: 1},
+		RawValueRepresentation: "US",
+		Value:                  badChildValue,
+	}
+	seqValue, err := NewValue([][]*Element{{badChild}})
+	if err != nil {
+		t.Fatalf("NewValue returned error: %v", err)
+	}
+	seqElem := &Element{
+		Tag:                    tag.Tag{Group: 2, Element: 2},
+		RawValueRepresentation: "SQ",
+		Value:                  seqValue,
+	}
+	if err := ValidateElement(seqElem); !errors.Is(err, ErrVRMismatch) {
+		t.Errorf("ValidateElement(sequence with a bad child) = %v, want ErrVRMismatch", err)
+	}
+}